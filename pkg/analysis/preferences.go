@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"context"
+	"reflect"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// preferencesCacheSize bounds how many slots' worth of `payload_attributes`
+// events PreferencesMonitor retains -- only the handful of slots currently
+// in flight are ever checked against an incoming bid.
+const preferencesCacheSize = 32
+
+// CheckPayloadPreferences compares a relay's bid against the proposer's
+// declared preferences for the same slot (the fee recipient and withdrawals
+// from the `payload_attributes` event) and records a fault in `faults` when
+// the bid ignores them.
+func CheckPayloadPreferences(faults *FaultRecord, publicKey types.PublicKey, bid *types.Bid, preferences consensus.PayloadAttributesEvent) {
+	record := faults.getOrCreate(publicKey)
+
+	record.Lock()
+	defer record.Unlock()
+	if bid.FeeRecipient != preferences.FeeRecipient || !withdrawalsEqual(bid.Withdrawals, preferences.Withdrawals) {
+		record.IgnoredPreferencesBids++
+	}
+}
+
+// withdrawalsEqual reports whether a and b list the same withdrawals in the
+// same order, treating a nil slice and an empty slice as equal -- "no
+// withdrawals" can arrive as either depending on whether it came from an
+// unmarshaled bid or a beacon node's `payload_attributes` event.
+func withdrawalsEqual(a, b []types.Withdrawal) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !reflect.DeepEqual(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// PreferencesMonitor retains the most recently observed `payload_attributes`
+// event for each in-flight slot, so CheckBid can compare a bid against the
+// proposer's preferences whichever order the two arrive in.
+type PreferencesMonitor struct {
+	faults      *FaultRecord
+	preferences *lru.Cache
+}
+
+// NewPreferencesMonitor returns a monitor that records faults into
+// `faults`.
+func NewPreferencesMonitor(faults *FaultRecord) (*PreferencesMonitor, error) {
+	cache, err := lru.New(preferencesCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &PreferencesMonitor{faults: faults, preferences: cache}, nil
+}
+
+// Run subscribes to `payload_attributes` events from `client` and retains
+// them by slot until `ctx` is canceled, so CheckBid always has the latest
+// preferences for any in-flight slot.
+func (m *PreferencesMonitor) Run(ctx context.Context, client *consensus.Client) {
+	for event := range client.StreamPayloadAttributes(ctx) {
+		m.preferences.Add(event.ProposalSlot, event)
+	}
+}
+
+// CheckBid compares `bid` against the cached proposer preferences for
+// `slot`, recording a fault via CheckPayloadPreferences when they conflict.
+// It reports false if no preferences have been observed for `slot` yet, in
+// which case no comparison was made.
+func (m *PreferencesMonitor) CheckBid(publicKey types.PublicKey, slot types.Slot, bid *types.Bid) bool {
+	cached, ok := m.preferences.Get(slot)
+	if !ok {
+		return false
+	}
+	CheckPayloadPreferences(m.faults, publicKey, bid, cached.(consensus.PayloadAttributesEvent))
+	return true
+}
@@ -1,10 +1,63 @@
 package analysis
 
-import "github.com/ralexstokes/relay-monitor/pkg/types"
+import (
+	"sync"
 
-type FaultRecord = map[types.PublicKey]*Faults
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
 
+// FaultRecord tracks each validator's observed relay faults. It is shared
+// between the bid-ingestion path and monitors like ReorgMonitor and
+// PreferencesMonitor that mutate it in the background, so every access to
+// the underlying map -- not just the counters inside a *Faults record --
+// goes through mu.
+type FaultRecord struct {
+	mu     sync.RWMutex
+	faults map[types.PublicKey]*Faults
+}
+
+// NewFaultRecord returns an empty FaultRecord ready to be shared across
+// goroutines.
+func NewFaultRecord() *FaultRecord {
+	return &FaultRecord{faults: make(map[types.PublicKey]*Faults)}
+}
+
+// getOrCreate returns the Faults record for publicKey, creating and
+// inserting an empty one if this is the first fault observed for it.
+func (r *FaultRecord) getOrCreate(publicKey types.PublicKey) *Faults {
+	r.mu.RLock()
+	record, ok := r.faults[publicKey]
+	r.mu.RUnlock()
+	if ok {
+		return record
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if record, ok := r.faults[publicKey]; ok {
+		return record
+	}
+	record = &Faults{}
+	r.faults[publicKey] = record
+	return record
+}
+
+// get returns the Faults record for publicKey, or reports false if none has
+// been recorded yet.
+func (r *FaultRecord) get(publicKey types.PublicKey) (*Faults, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	record, ok := r.faults[publicKey]
+	return record, ok
+}
+
+// Faults counts a validator's observed relay faults. Counters are mutated
+// both from the bid-ingestion path and, in the background, by monitors like
+// ReorgMonitor that recompute a classification after the fact, so every
+// read-modify-write of a counter must hold mu.
 type Faults struct {
+	mu sync.Mutex
+
 	TotalBids uint `json:"total_bids"`
 
 	ConsensusInvalidBids   uint `json:"consensus_invalid_bids"`
@@ -14,4 +67,20 @@ type Faults struct {
 	MalformedPayloads        uint `json:"malformed_payloads"`
 	ConsensusInvalidPayloads uint `json:"consensus_invalid_payloads"`
 	UnavailablePayloads      uint `json:"unavailable_payloads"`
+
+	// ReorgAdjustedBids counts bids that were originally counted against a
+	// canonical execution hash that a later chain reorg invalidated, and
+	// whose recomputed fault classification came out different.
+	ReorgAdjustedBids uint `json:"reorg_adjusted_bids"`
+}
+
+// Lock and Unlock expose Faults' internal mutex so callers that need to
+// read-modify-write several counters together (PreferencesMonitor,
+// ReorgMonitor) can hold it across the whole operation.
+func (f *Faults) Lock() {
+	f.mu.Lock()
+}
+
+func (f *Faults) Unlock() {
+	f.mu.Unlock()
 }
@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"context"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/ralexstokes/relay-monitor/pkg/consensus"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// classificationCacheSize bounds how many slots of bid classifications
+// ReorgMonitor retains -- only slots within a plausible reorg depth are
+// ever re-verified.
+const classificationCacheSize = 32
+
+// BidClassification is the consensus-validity verdict recorded for a bid at
+// the time it was checked, so a later reorg can tell whether that verdict
+// still holds against the new canonical execution hash.
+type BidClassification struct {
+	PublicKey      types.PublicKey
+	ExecutionHash  types.Hash
+	ConsensusValid bool
+}
+
+// CheckConsensusValidity compares a relay's bid against the slot's current
+// canonical execution hash, recording a fault in `faults` when they
+// disagree. It returns the classification so ReorgMonitor can re-verify it
+// if the slot is later reorged.
+func CheckConsensusValidity(faults *FaultRecord, publicKey types.PublicKey, slot types.Slot, bidExecutionHash, canonicalExecutionHash types.Hash) BidClassification {
+	record := faults.getOrCreate(publicKey)
+
+	record.Lock()
+	record.TotalBids++
+	valid := bidExecutionHash == canonicalExecutionHash
+	if !valid {
+		record.ConsensusInvalidBids++
+	}
+	record.Unlock()
+
+	return BidClassification{PublicKey: publicKey, ExecutionHash: bidExecutionHash, ConsensusValid: valid}
+}
+
+// ReorgMonitor retains the consensus-validity classification of recently
+// checked bids by slot, and re-verifies them against the new canonical
+// execution hash whenever a reorg invalidates the one they were originally
+// checked against.
+type ReorgMonitor struct {
+	faults          *FaultRecord
+	classifications *lru.Cache
+}
+
+// NewReorgMonitor returns a monitor that adjusts faults recorded in
+// `faults` as reorgs invalidate the classifications they were based on.
+func NewReorgMonitor(faults *FaultRecord) (*ReorgMonitor, error) {
+	cache, err := lru.New(classificationCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ReorgMonitor{faults: faults, classifications: cache}, nil
+}
+
+// Record retains `classification` for `slot` so it can be re-verified if
+// the slot is later reorged. Callers should call this alongside every
+// CheckConsensusValidity call.
+func (m *ReorgMonitor) Record(slot types.Slot, classification BidClassification) {
+	existing, _ := m.classifications.Get(slot)
+	list, _ := existing.([]BidClassification)
+	m.classifications.Add(slot, append(list, classification))
+}
+
+// Run subscribes to chain reorgs from `client` and re-verifies every bid
+// classification recorded for the affected slots against the new canonical
+// execution hash, adjusting ConsensusInvalidBids and incrementing
+// ReorgAdjustedBids for each classification the reorg flipped, until `ctx`
+// is canceled.
+func (m *ReorgMonitor) Run(ctx context.Context, client *consensus.Client) {
+	for event := range client.StreamReorgs(ctx) {
+		for i := uint64(0); i <= event.Depth; i++ {
+			m.reverify(client, event.Slot-types.Slot(i))
+		}
+	}
+}
+
+func (m *ReorgMonitor) reverify(client *consensus.Client, slot types.Slot) {
+	cached, ok := m.classifications.Get(slot)
+	if !ok {
+		return
+	}
+	classifications, _ := cached.([]BidClassification)
+
+	canonicalExecutionHash, err := client.GetExecutionHash(slot)
+	if err != nil {
+		return
+	}
+
+	for i, classification := range classifications {
+		record, ok := m.faults.get(classification.PublicKey)
+		if !ok {
+			continue
+		}
+
+		record.Lock()
+		stillValid := classification.ExecutionHash == canonicalExecutionHash
+		if stillValid != classification.ConsensusValid {
+			if stillValid {
+				record.ConsensusInvalidBids--
+			} else {
+				record.ConsensusInvalidBids++
+			}
+			record.ReorgAdjustedBids++
+			classifications[i].ConsensusValid = stillValid
+		}
+		record.Unlock()
+	}
+}
@@ -2,12 +2,10 @@ package consensus
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
-	"strconv"
 	"strings"
-	"time"
+	"sync"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,9 +13,7 @@ import (
 	"github.com/protolambda/eth2api"
 	"github.com/protolambda/eth2api/client/beaconapi"
 	"github.com/protolambda/eth2api/client/validatorapi"
-	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
 	"github.com/protolambda/zrnt/eth2/beacon/common"
-	"github.com/r3labs/sse/v2"
 	"github.com/ralexstokes/relay-monitor/pkg/types"
 	"go.uber.org/zap"
 )
@@ -25,6 +21,16 @@ import (
 const (
 	clientTimeoutSec = 30
 	cacheSize        = 128
+
+	// defaultValidatorCacheSize is used when the caller does not configure
+	// an explicit validator cache size. Mainnet has hundreds of thousands of
+	// validators, so this is deliberately much larger than `cacheSize`.
+	defaultValidatorCacheSize = 1 << 16
+
+	// validatorBatchSize bounds how many pubkeys/indices are requested from
+	// a single `StateValidators` call so a burst of cache misses can't force
+	// one massive request against the beacon node.
+	validatorBatchSize = 256
 )
 
 type ValidatorInfo struct {
@@ -34,7 +40,9 @@ type ValidatorInfo struct {
 
 type Client struct {
 	logger *zap.Logger
-	client *eth2api.Eth2HttpClient
+	pool   *endpointPool
+
+	slotsPerEpoch uint64
 
 	// slot -> ValidatorInfo
 	proposerCache *lru.Cache
@@ -42,6 +50,48 @@ type Client struct {
 	executionCache *lru.Cache
 	// publicKey -> Validator
 	validatorCache *lru.Cache
+	// index -> publicKey, populated alongside `validatorCache` so lookups in
+	// either direction never need a second RPC round-trip.
+	validatorIndexCache *lru.Cache
+	// publicKey -> struct{}, validators observed via an EIP-6110 deposit
+	// request but not yet returned by a `StateValidators` sweep.
+	pendingValidatorCache *lru.Cache
+
+	rawDepositEvents chan DepositEvent
+	depositEvents    chan DepositEvent
+
+	// events is the client's single shared multi-topic SSE subscription;
+	// every `Stream*` wrapper reads from its already-demuxed channels
+	// instead of opening a subscription of its own. Each topic's channel
+	// has exactly one consumer goroutine on the other end, so each
+	// `Stream*` method may only be called once per Client -- streamed and
+	// streamedMu enforce that.
+	events     *Events
+	streamed   map[string]bool
+	streamedMu sync.Mutex
+}
+
+// claimStream panics if `topic` has already been handed to a `Stream*`
+// caller. Every topic's channel on the shared subscription has a single
+// production-side forwarding goroutine; a second consumer would race the
+// first over the same channel and each would see only a random subset of
+// events instead of the full stream.
+func (c *Client) claimStream(topic string) {
+	c.streamedMu.Lock()
+	defer c.streamedMu.Unlock()
+	if c.streamed[topic] {
+		panic(fmt.Sprintf("consensus: Client's %q stream was already claimed by an earlier Stream* call", topic))
+	}
+	c.streamed[topic] = true
+}
+
+// DepositEvent reports an EIP-6110 deposit request observed directly in an
+// execution payload, ahead of the validator appearing in beacon state.
+type DepositEvent struct {
+	Slot                  types.Slot
+	PublicKey             types.PublicKey
+	WithdrawalCredentials types.Root
+	Amount                uint64
 }
 
 var (
@@ -57,18 +107,20 @@ var (
 		Name: "relay_monitor_validator_cache_length",
 		Help: "The size of the validator cache",
 	})
+	elDepositsObservedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "relay_monitor_el_deposits_observed_total",
+		Help: "The number of EIP-6110 execution-layer deposit requests observed",
+	})
 )
 
-func NewClient(ctx context.Context, endpoint string, logger *zap.Logger, currentSlot types.Slot, currentEpoch types.Epoch, slotsPerEpoch uint64) (*Client, error) {
-	httpClient := &eth2api.Eth2HttpClient{
-		Addr: endpoint,
-		Cli: &http.Client{
-			Transport: &http.Transport{
-				MaxIdleConnsPerHost: 128,
-			},
-			Timeout: clientTimeoutSec * time.Second,
-		},
-		Codec: eth2api.JSONCodec{},
+func NewClient(ctx context.Context, endpoints []string, logger *zap.Logger, currentSlot types.Slot, currentEpoch types.Epoch, slotsPerEpoch uint64, validatorCacheSize int) (*Client, error) {
+	if validatorCacheSize <= 0 {
+		validatorCacheSize = defaultValidatorCacheSize
+	}
+
+	pool, err := newEndpointPool(logger, endpoints)
+	if err != nil {
+		return nil, err
 	}
 
 	proposerCache, err := lru.New(cacheSize)
@@ -83,20 +135,43 @@ func NewClient(ctx context.Context, endpoint string, logger *zap.Logger, current
 	}
 	executionCacheGauge.Set(0.0)
 
-	validatorCache, err := lru.New(cacheSize)
+	validatorCache, err := lru.NewWithEvict(validatorCacheSize, func(key, value interface{}) {
+		validatorCacheGauge.Add(-1.0)
+	})
 	if err != nil {
 		return nil, err
 	}
 	validatorCacheGauge.Set(0.0)
 
+	validatorIndexCache, err := lru.New(validatorCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingValidatorCache, err := lru.New(validatorCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	client := &Client{
-		logger:         logger,
-		client:         httpClient,
-		proposerCache:  proposerCache,
-		executionCache: executionCache,
-		validatorCache: validatorCache,
+		logger:                logger,
+		pool:                  pool,
+		slotsPerEpoch:         slotsPerEpoch,
+		proposerCache:         proposerCache,
+		executionCache:        executionCache,
+		validatorCache:        validatorCache,
+		validatorIndexCache:   validatorIndexCache,
+		pendingValidatorCache: pendingValidatorCache,
+		rawDepositEvents:      make(chan DepositEvent, 32),
+		depositEvents:         make(chan DepositEvent, 32),
+		streamed:              make(map[string]bool),
 	}
 
+	client.events = client.Subscribe(ctx, topicHead, topicBlock, topicFinalizedCheckpoint, topicChainReorg, topicPayloadAttributes)
+
+	go client.processDepositEvents(ctx)
+	go pool.probeSyncing(ctx)
+
 	err = client.loadCurrentContext(ctx, currentSlot, currentEpoch, slotsPerEpoch)
 	if err != nil {
 		logger := logger.Sugar()
@@ -106,6 +181,32 @@ func NewClient(ctx context.Context, endpoint string, logger *zap.Logger, current
 	return client, nil
 }
 
+// processDepositEvents upgrades `pendingValidatorCache` as EIP-6110 deposit
+// requests are observed, so `GetValidatorStatus` can answer immediately
+// instead of waiting for the next full `FetchValidators`-style sweep.
+func (c *Client) processDepositEvents(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-c.rawDepositEvents:
+			c.pendingValidatorCache.Add(event.PublicKey, struct{}{})
+			select {
+			case c.depositEvents <- event:
+			default:
+				c.logger.Sugar().Warnw("dropping deposit event, subscriber channel is full", "slot", event.Slot)
+			}
+		}
+	}
+}
+
+// DepositEvents returns a channel of EIP-6110 deposit requests observed in
+// execution payloads, ahead of the deposited validator appearing in beacon
+// state.
+func (c *Client) DepositEvents() <-chan DepositEvent {
+	return c.depositEvents
+}
+
 func (c *Client) loadCurrentContext(ctx context.Context, currentSlot types.Slot, currentEpoch types.Epoch, slotsPerEpoch uint64) error {
 	logger := c.logger.Sugar()
 
@@ -132,14 +233,34 @@ func (c *Client) loadCurrentContext(ctx context.Context, currentSlot types.Slot,
 		logger.Warnf("could not load consensus state for epoch %d: %v", nextEpoch, err)
 	}
 
-	err = c.FetchValidators(ctx)
+	_, err = c.GetValidatorsByPubkeys(ctx, c.proposerCachePubkeys())
 	if err != nil {
-		logger.Warnf("could not load validators: %v", err)
+		logger.Warnf("could not load validators for known proposer duties: %v", err)
 	}
 
 	return nil
 }
 
+// proposerCachePubkeys returns the public keys of every proposer currently
+// held in `proposerCache`, i.e. the validators the monitor actually expects
+// to see bids for in the current and next epoch.
+func (c *Client) proposerCachePubkeys() []types.PublicKey {
+	keys := c.proposerCache.Keys()
+	pubkeys := make([]types.PublicKey, 0, len(keys))
+	for _, key := range keys {
+		val, ok := c.proposerCache.Peek(key)
+		if !ok {
+			continue
+		}
+		validator, ok := val.(ValidatorInfo)
+		if !ok {
+			continue
+		}
+		pubkeys = append(pubkeys, validator.publicKey)
+	}
+	return pubkeys
+}
+
 func (c *Client) GetProposer(slot types.Slot) (*ValidatorInfo, error) {
 	val, ok := c.proposerCache.Get(slot)
 	if !ok {
@@ -153,19 +274,27 @@ func (c *Client) GetProposer(slot types.Slot) (*ValidatorInfo, error) {
 }
 
 func (c *Client) GetExecutionHash(slot types.Slot) (types.Hash, error) {
+	entry, err := c.getExecutionPayloadSummary(slot)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return entry.blockHash, nil
+}
+
+func (c *Client) getExecutionPayloadSummary(slot types.Slot) (executionPayloadSummary, error) {
 	val, ok := c.executionCache.Get(slot)
 	if !ok {
-		return types.Hash{}, fmt.Errorf("could not find execution hash for slot %d", slot)
+		return executionPayloadSummary{}, fmt.Errorf("could not find execution hash for slot %d", slot)
 	}
-	hash, ok := val.(types.Hash)
+	entry, ok := val.(executionPayloadSummary)
 	if !ok {
-		return types.Hash{}, fmt.Errorf("internal: execution cache contains an unexpected type %T", val)
+		return executionPayloadSummary{}, fmt.Errorf("internal: execution cache contains an unexpected type %T", val)
 	}
-	return hash, nil
+	return entry, nil
 }
 
 func (c *Client) GetValidator(publicKey *types.PublicKey) (*eth2api.ValidatorResponse, error) {
-	val, ok := c.validatorCache.Get(publicKey)
+	val, ok := c.validatorCache.Get(*publicKey)
 	if !ok {
 		return nil, fmt.Errorf("missing validator entry for public key %s", publicKey)
 	}
@@ -176,6 +305,31 @@ func (c *Client) GetValidator(publicKey *types.PublicKey) (*eth2api.ValidatorRes
 	return &validator, nil
 }
 
+// GetValidatorByIndex resolves a validator's public key via
+// `validatorIndexCache` and returns its cached entry, without a second RPC.
+func (c *Client) GetValidatorByIndex(index types.ValidatorIndex) (*eth2api.ValidatorResponse, error) {
+	val, ok := c.validatorIndexCache.Get(index)
+	if !ok {
+		return nil, fmt.Errorf("missing validator index entry for index %d", index)
+	}
+	publicKey, ok := val.(types.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("internal: validator index cache contains an unexpected type %T", val)
+	}
+	return c.GetValidator(&publicKey)
+}
+
+func (c *Client) cacheValidator(validator eth2api.ValidatorResponse) {
+	publicKey := types.PublicKey(validator.Validator.Pubkey)
+	index := types.ValidatorIndex(validator.Index)
+	if _, existed := c.validatorCache.Peek(publicKey); !existed {
+		validatorCacheGauge.Add(1.0)
+	}
+	c.validatorCache.Add(publicKey, validator)
+	c.validatorIndexCache.Add(index, publicKey)
+	c.pendingValidatorCache.Remove(publicKey)
+}
+
 func (c *Client) GetParentHash(ctx context.Context, slot types.Slot) (types.Hash, error) {
 	targetSlot := slot - 1
 	parentHash, err := c.GetExecutionHash(targetSlot)
@@ -196,20 +350,26 @@ func (c *Client) GetProposerPublicKey(ctx context.Context, slot types.Slot) (*ty
 
 func (c *Client) FetchProposers(ctx context.Context, epoch types.Epoch) error {
 	var proposerDuties eth2api.DependentProposerDuty
-	syncing, err := validatorapi.ProposerDuties(ctx, c.client, common.Epoch(epoch), &proposerDuties)
-	if syncing {
-		return fmt.Errorf("could not fetch proposal duties in epoch %d because node is syncing", epoch)
-	} else if err != nil {
+	err := c.pool.withFailover(func(client *eth2api.Eth2HttpClient) error {
+		syncing, err := validatorapi.ProposerDuties(ctx, client, common.Epoch(epoch), &proposerDuties)
+		if syncing {
+			return fmt.Errorf("could not fetch proposal duties in epoch %d because node is syncing", epoch)
+		}
+		return err
+	})
+	if err != nil {
 		return err
 	}
 
-	// TODO handle reorgs, etc.
 	for _, duty := range proposerDuties.Data {
-		c.proposerCache.Add(uint64(duty.Slot), ValidatorInfo{
+		slot := uint64(duty.Slot)
+		if _, existed := c.proposerCache.Peek(slot); !existed {
+			proposerCacheGauge.Add(1.0)
+		}
+		c.proposerCache.Add(slot, ValidatorInfo{
 			publicKey: types.PublicKey(duty.Pubkey),
 			index:     uint64(duty.ValidatorIndex),
 		})
-		proposerCacheGauge.Add(1.0)
 	}
 
 	return nil
@@ -218,20 +378,21 @@ func (c *Client) FetchProposers(ctx context.Context, epoch types.Epoch) error {
 func (c *Client) backFillExecutionHash(slot types.Slot) (types.Hash, error) {
 	for i := slot; i > 0; i-- {
 		targetSlot := i - 1
-		executionHash, err := c.GetExecutionHash(targetSlot)
+		entry, err := c.getExecutionPayloadSummary(targetSlot)
 		if err == nil {
 			for i := targetSlot; i < slot; i++ {
-				c.executionCache.Add(i+1, executionHash)
-				executionCacheGauge.Add(1.0)
+				if _, existed := c.executionCache.Peek(i + 1); !existed {
+					executionCacheGauge.Add(1.0)
+				}
+				c.executionCache.Add(i+1, entry)
 			}
-			return executionHash, nil
+			return entry.blockHash, nil
 		}
 	}
 	return types.Hash{}, fmt.Errorf("no execution hashes present before %d (inclusive)", slot)
 }
 
 func (c *Client) FetchExecutionHash(ctx context.Context, slot types.Slot) (types.Hash, error) {
-	// TODO handle reorgs, etc.
 	executionHash, err := c.GetExecutionHash(slot)
 	if err == nil {
 		return executionHash, nil
@@ -240,7 +401,12 @@ func (c *Client) FetchExecutionHash(ctx context.Context, slot types.Slot) (types
 	blockID := eth2api.BlockIdSlot(slot)
 
 	var signedBeaconBlock eth2api.VersionedSignedBeaconBlock
-	exists, err := beaconapi.BlockV2(ctx, c.client, blockID, &signedBeaconBlock)
+	var exists bool
+	err = c.pool.withReadFailover(func(client *eth2api.Eth2HttpClient) error {
+		var err error
+		exists, err = beaconapi.BlockV2(ctx, client, blockID, &signedBeaconBlock)
+		return err
+	})
 	if !exists {
 		// TODO move search to `GetParentHash`
 		// TODO also instantiate with first execution hash...
@@ -249,78 +415,246 @@ func (c *Client) FetchExecutionHash(ctx context.Context, slot types.Slot) (types
 		return types.Hash{}, err
 	}
 
-	bellatrixBlock, ok := signedBeaconBlock.Data.(*bellatrix.SignedBeaconBlock)
-	if !ok {
-		return types.Hash{}, fmt.Errorf("could not parse block %s", signedBeaconBlock)
+	entry, err := extractExecutionPayload(&signedBeaconBlock)
+	if errors.Is(err, ErrPreBellatrixBlock) {
+		return c.backFillExecutionHash(slot)
+	} else if err != nil {
+		return types.Hash{}, err
 	}
-	executionHash = types.Hash(bellatrixBlock.Message.Body.ExecutionPayload.BlockHash)
 
-	// TODO handle reorgs, etc.
-	c.executionCache.Add(slot, executionHash)
-	executionCacheGauge.Add(1.0)
+	if _, existed := c.executionCache.Peek(slot); !existed {
+		executionCacheGauge.Add(1.0)
+	}
+	c.executionCache.Add(slot, entry)
+
+	for _, deposit := range entry.deposits {
+		elDepositsObservedCounter.Inc()
+		c.logger.Sugar().Debugw("observed EIP-6110 execution-layer deposit", "slot", slot, "publicKey", deposit.publicKey)
+		c.rawDepositEvents <- DepositEvent{
+			Slot:                  slot,
+			PublicKey:             deposit.publicKey,
+			WithdrawalCredentials: deposit.withdrawalCredentials,
+			Amount:                deposit.amount,
+		}
+	}
 
-	return executionHash, nil
+	return entry.blockHash, nil
 }
 
-type headEvent struct {
-	Slot  string     `json:"slot"`
-	Block types.Root `json:"block"`
+func (c *Client) epochForSlot(slot types.Slot) types.Epoch {
+	return types.Epoch(uint64(slot) / c.slotsPerEpoch)
 }
 
-func (c *Client) StreamHeads(ctx context.Context) <-chan types.Coordinate {
+// handleReorg evicts the now-stale execution hashes for `[slot-depth, slot]`
+// and re-fetches proposer duties for any epoch spanned by that range, so that
+// `GetExecutionHash`/`GetProposer` never answer with data from an
+// abandoned fork. A depth of zero is a no-op: the head changed without
+// reorganizing any slots. Validators are never evicted from
+// `validatorCache` on a reorg -- validator identity does not change across
+// forks of the same chain.
+//
+// It does blocking RPCs, so `dispatchEvent` runs it in its own goroutine
+// rather than inline in the shared SSE read loop -- otherwise a reorg would
+// stall every other topic on that connection until it finished. It only
+// forwards `event` to `events.Reorg` once the refetch above has completed,
+// so a consumer like ReorgMonitor never reverifies a slot's classification
+// against the still-cached pre-reorg execution hash.
+func (c *Client) handleReorg(ctx context.Context, event ReorgEvent, events *Events) {
 	logger := c.logger.Sugar()
 
-	sseClient := sse.NewClient(c.client.Addr + "/eth/v1/events?topics=head")
-	ch := make(chan types.Coordinate, 1)
-	go func() {
-		err := sseClient.SubscribeRawWithContext(ctx, func(msg *sse.Event) {
-			var event headEvent
-			err := json.Unmarshal(msg.Data, &event)
-			if err != nil {
-				logger.Warnf("could not unmarshal `head` node event: %v", err)
-				return
+	if event.Depth > 0 {
+		staleEpochs := make(map[types.Epoch]struct{})
+		for i := uint64(0); i <= event.Depth; i++ {
+			slot := event.Slot - types.Slot(i)
+			if _, existed := c.executionCache.Peek(slot); existed {
+				c.executionCache.Remove(slot)
+				executionCacheGauge.Add(-1.0)
 			}
-			slot, err := strconv.Atoi(event.Slot)
-			if err != nil {
-				logger.Warnf("could not unmarshal slot from `head` node event: %v", err)
-				return
+			staleEpochs[c.epochForSlot(slot)] = struct{}{}
+		}
+
+		for epoch := range staleEpochs {
+			if err := c.FetchProposers(ctx, epoch); err != nil {
+				logger.Warnf("could not refresh proposer duties for epoch %d after reorg: %v", epoch, err)
 			}
-			head := types.Coordinate{
-				Slot: types.Slot(slot),
-				Root: event.Block,
+		}
+
+		for i := uint64(0); i <= event.Depth; i++ {
+			slot := event.Slot - types.Slot(i)
+			if _, err := c.FetchExecutionHash(ctx, slot); err != nil {
+				logger.Warnf("could not refresh execution hash for slot %d after reorg: %v", slot, err)
 			}
-			ch <- head
-		})
-		if err != nil {
-			logger.Errorw("could not subscribe to head event", "error", err)
+		}
+	}
+
+	if events.Reorg != nil {
+		events.Reorg <- event
+	}
+}
+
+// pruneBeforeFinalized drops proposer/execution cache entries for slots that
+// can no longer reorg, now that `checkpoint` has finalized. Validators are
+// left alone -- the validator set only grows.
+func (c *Client) pruneBeforeFinalized(checkpoint FinalizedCheckpointEvent) {
+	finalizedSlot := types.Slot(uint64(checkpoint.Epoch) * c.slotsPerEpoch)
+
+	for _, key := range c.proposerCache.Keys() {
+		if slot, ok := key.(types.Slot); ok && slot < finalizedSlot {
+			c.proposerCache.Remove(key)
+			proposerCacheGauge.Add(-1.0)
+		}
+	}
+	for _, key := range c.executionCache.Keys() {
+		if slot, ok := key.(types.Slot); ok && slot < finalizedSlot {
+			c.executionCache.Remove(key)
+			executionCacheGauge.Add(-1.0)
+		}
+	}
+}
+
+// StreamHeads returns the client's shared subscription's `head` channel; it
+// is a thin convenience wrapper for callers that only care about new heads.
+// It may only be called once per Client -- a second call panics, since the
+// underlying channel has a single forwarding consumer and two callers
+// racing over it would each see only a random subset of heads.
+func (c *Client) StreamHeads(ctx context.Context) <-chan types.Coordinate {
+	c.claimStream(topicHead)
+	ch := make(chan types.Coordinate, 1)
+	go func() {
+		for event := range c.events.Head {
+			ch <- event.Coordinate
 		}
 	}()
 	return ch
 }
 
-// TODO handle reorgs
-func (c *Client) FetchValidators(ctx context.Context) error {
-	var response []eth2api.ValidatorResponse
-	exists, err := beaconapi.StateValidators(ctx, c.client, eth2api.StateHead, nil, nil, &response)
-	if err != nil {
-		return err
+// StreamReorgs returns the client's shared subscription's `chain_reorg`
+// channel; it is a thin convenience wrapper for callers that only care
+// about reorgs. It may only be called once per Client -- a second call
+// panics, since the underlying channel has a single consumer and two
+// callers racing over it would each see only a random subset of reorgs.
+func (c *Client) StreamReorgs(ctx context.Context) <-chan ReorgEvent {
+	c.claimStream(topicChainReorg)
+	return c.events.Reorg
+}
+
+// StreamPayloadAttributes returns the client's shared subscription's
+// `payload_attributes` channel; it is a thin convenience wrapper for
+// callers that only care about the proposer's declared payload
+// preferences. It may only be called once per Client -- a second call
+// panics, since the underlying channel has a single consumer and two
+// callers racing over it would each see only a random subset of events.
+func (c *Client) StreamPayloadAttributes(ctx context.Context) <-chan PayloadAttributesEvent {
+	c.claimStream(topicPayloadAttributes)
+	return c.events.PayloadAttributes
+}
+
+// GetValidatorsByPubkeys resolves `pubkeys` to their validator entries,
+// serving cache hits directly and coalescing every miss into batched
+// `StateValidators` calls of at most `validatorBatchSize` pubkeys each, so a
+// large lookup never turns into one unbounded request against the beacon
+// node.
+func (c *Client) GetValidatorsByPubkeys(ctx context.Context, pubkeys []types.PublicKey) ([]eth2api.ValidatorResponse, error) {
+	validators := make([]eth2api.ValidatorResponse, 0, len(pubkeys))
+	var misses []types.PublicKey
+	for _, publicKey := range pubkeys {
+		if val, ok := c.validatorCache.Get(publicKey); ok {
+			validators = append(validators, val.(eth2api.ValidatorResponse))
+		} else {
+			misses = append(misses, publicKey)
+		}
 	}
-	if !exists {
-		return fmt.Errorf("could not fetch validators from remote endpoint because they do not exist")
+
+	for start := 0; start < len(misses); start += validatorBatchSize {
+		end := start + validatorBatchSize
+		if end > len(misses) {
+			end = len(misses)
+		}
+		batch := misses[start:end]
+
+		ids := make([]eth2api.ValidatorId, 0, len(batch))
+		for _, publicKey := range batch {
+			ids = append(ids, eth2api.ValidatorIdPubkey(common.BLSPubkey(publicKey)))
+		}
+
+		var response []eth2api.ValidatorResponse
+		var exists bool
+		err := c.pool.withFailover(func(client *eth2api.Eth2HttpClient) error {
+			var err error
+			exists, err = beaconapi.StateValidators(ctx, client, eth2api.StateHead, ids, nil, &response)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("could not fetch validators from remote endpoint because they do not exist")
+		}
+
+		for _, validator := range response {
+			c.cacheValidator(validator)
+			validators = append(validators, validator)
+		}
 	}
 
-	for _, validator := range response {
-		publicKey := validator.Validator.Pubkey
-		c.validatorCache.Add(publicKey, validator)
-		validatorCacheGauge.Add(1.0)
+	return validators, nil
+}
+
+// GetValidatorsByIndices is the index-keyed counterpart of
+// `GetValidatorsByPubkeys`: cache hits resolve via `validatorIndexCache`, and
+// every miss is coalesced into batched `StateValidators` calls.
+func (c *Client) GetValidatorsByIndices(ctx context.Context, indices []types.ValidatorIndex) ([]eth2api.ValidatorResponse, error) {
+	validators := make([]eth2api.ValidatorResponse, 0, len(indices))
+	var misses []types.ValidatorIndex
+	for _, index := range indices {
+		if validator, err := c.GetValidatorByIndex(index); err == nil {
+			validators = append(validators, *validator)
+		} else {
+			misses = append(misses, index)
+		}
 	}
 
-	return nil
+	for start := 0; start < len(misses); start += validatorBatchSize {
+		end := start + validatorBatchSize
+		if end > len(misses) {
+			end = len(misses)
+		}
+		batch := misses[start:end]
+
+		ids := make([]eth2api.ValidatorId, 0, len(batch))
+		for _, index := range batch {
+			ids = append(ids, eth2api.ValidatorIdIndex(common.ValidatorIndex(index)))
+		}
+
+		var response []eth2api.ValidatorResponse
+		var exists bool
+		err := c.pool.withFailover(func(client *eth2api.Eth2HttpClient) error {
+			var err error
+			exists, err = beaconapi.StateValidators(ctx, client, eth2api.StateHead, ids, nil, &response)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("could not fetch validators from remote endpoint because they do not exist")
+		}
+
+		for _, validator := range response {
+			c.cacheValidator(validator)
+			validators = append(validators, validator)
+		}
+	}
+
+	return validators, nil
 }
 
 func (c *Client) GetValidatorStatus(publicKey *types.PublicKey) (ValidatorStatus, error) {
 	validator, err := c.GetValidator(publicKey)
 	if err != nil {
+		if _, pending := c.pendingValidatorCache.Get(*publicKey); pending {
+			return StatusValidatorPending, nil
+		}
 		return StatusValidatorUnknown, err
 	}
 	validatorStatus := string(validator.Status)
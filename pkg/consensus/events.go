@@ -0,0 +1,312 @@
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/r3labs/sse/v2"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// dedupeCacheSize bounds how many recently-seen `(topic, slot, root)` keys
+// are remembered so that re-subscribing to a new endpoint after a dropped
+// SSE connection doesn't double-deliver an event downstream consumers in
+// `pkg/analysis` already saw.
+const dedupeCacheSize = 256
+
+// reconnectBackoff is the pause between SSE re-subscribe attempts against
+// the next healthy endpoint.
+const reconnectBackoff = 2 * time.Second
+
+const (
+	topicHead                = "head"
+	topicBlock               = "block"
+	topicFinalizedCheckpoint = "finalized_checkpoint"
+	topicChainReorg          = "chain_reorg"
+	topicPayloadAttributes   = "payload_attributes"
+)
+
+// HeadEvent reports a new canonical head, as reported by the `head` topic.
+type HeadEvent struct {
+	Coordinate types.Coordinate
+}
+
+// BlockEvent reports a new block becoming known to the node, as reported by
+// the `block` topic.
+type BlockEvent struct {
+	Slot  types.Slot
+	Block types.Root
+}
+
+// FinalizedCheckpointEvent reports a new finalized checkpoint, as reported
+// by the `finalized_checkpoint` topic.
+type FinalizedCheckpointEvent struct {
+	Epoch types.Epoch
+	Block types.Root
+}
+
+// ReorgEvent reports a chain reorganization, as reported by the
+// `chain_reorg` topic.
+type ReorgEvent struct {
+	Slot         types.Slot
+	Depth        uint64
+	OldHeadBlock types.Root
+	NewHeadBlock types.Root
+}
+
+// PayloadAttributesEvent reports the proposer's declared preferences for the
+// next slot's execution payload, as reported by the `payload_attributes`
+// topic.
+type PayloadAttributesEvent struct {
+	ProposalSlot types.Slot
+	FeeRecipient types.Address
+	Withdrawals  []types.Withdrawal
+}
+
+type headEvent struct {
+	Slot  string     `json:"slot"`
+	Block types.Root `json:"block"`
+}
+
+type blockEvent struct {
+	Slot  string     `json:"slot"`
+	Block types.Root `json:"block"`
+}
+
+type finalizedCheckpointEvent struct {
+	Epoch string     `json:"epoch"`
+	Block types.Root `json:"block"`
+}
+
+type reorgEvent struct {
+	Slot         string     `json:"slot"`
+	Depth        string     `json:"depth"`
+	OldHeadBlock types.Root `json:"old_head_block"`
+	NewHeadBlock types.Root `json:"new_head_block"`
+}
+
+type payloadAttributesEvent struct {
+	Data struct {
+		ProposalSlot      string `json:"proposal_slot"`
+		PayloadAttributes struct {
+			FeeRecipient types.Address      `json:"suggested_fee_recipient"`
+			Withdrawals  []types.Withdrawal `json:"withdrawals"`
+		} `json:"payload_attributes"`
+	} `json:"data"`
+}
+
+// Events is a bundle of per-topic channels populated by `Subscribe`. Only
+// the channels for the requested topics are non-nil. The `Events` returned
+// by `Client.Subscribe` internally during `NewClient` is shared by every
+// `Stream*` wrapper, and each of its channels supports exactly one
+// consumer -- see `Client.claimStream`.
+type Events struct {
+	Head                chan HeadEvent
+	Block               chan BlockEvent
+	FinalizedCheckpoint chan FinalizedCheckpointEvent
+	Reorg               chan ReorgEvent
+	PayloadAttributes   chan PayloadAttributesEvent
+}
+
+// Subscribe opens an SSE connection to `/eth/v1/events` for the given topics
+// against the pool's current primary endpoint and fans out decoded events
+// over per-topic channels on the returned `Events`. `NewClient` calls this
+// once with every topic the client needs, and the `Stream*` wrappers read
+// from the resulting channels, so a client with several subscribers never
+// opens more than this one connection. If the connection drops, it
+// re-subscribes against the next healthy endpoint, deduplicating `head`,
+// `block` and `chain_reorg` events by `(topic, slot, root)` so downstream
+// consumers don't double-count a reconnect that replays recent history;
+// `finalized_checkpoint` and `payload_attributes` events are not
+// deduplicated.
+func (c *Client) Subscribe(ctx context.Context, topics ...string) *Events {
+	logger := c.logger.Sugar()
+
+	events := &Events{}
+	for _, topic := range topics {
+		switch topic {
+		case topicHead:
+			events.Head = make(chan HeadEvent, 1)
+		case topicBlock:
+			events.Block = make(chan BlockEvent, 1)
+		case topicFinalizedCheckpoint:
+			events.FinalizedCheckpoint = make(chan FinalizedCheckpointEvent, 1)
+		case topicChainReorg:
+			events.Reorg = make(chan ReorgEvent, 1)
+		case topicPayloadAttributes:
+			events.PayloadAttributes = make(chan PayloadAttributesEvent, 1)
+		}
+	}
+
+	dedupe, err := lru.New(dedupeCacheSize)
+	if err != nil {
+		logger.Errorw("could not build event dedupe cache", "error", err)
+		return events
+	}
+
+	go func() {
+		for {
+			addr := c.pool.primaryAddr()
+			sseClient := sse.NewClient(addr + "/eth/v1/events?topics=" + strings.Join(topics, ","))
+			err := sseClient.SubscribeRawWithContext(ctx, func(msg *sse.Event) {
+				c.dispatchEvent(ctx, string(msg.Event), msg.Data, events, dedupe)
+			})
+
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logger.Errorw("event stream subscription dropped, reconnecting", "topics", topics, "endpoint", addr, "error", err)
+				c.pool.markUnhealthy(addr)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reconnectBackoff):
+			}
+		}
+	}()
+	return events
+}
+
+// seen reports whether `(topic, slot, root)` has already been dispatched
+// recently, recording it if not.
+func seen(dedupe *lru.Cache, topic string, slot types.Slot, root types.Root) bool {
+	key := fmt.Sprintf("%s:%d:%s", topic, slot, root)
+	if dedupe.Contains(key) {
+		return true
+	}
+	dedupe.Add(key, struct{}{})
+	return false
+}
+
+// dispatchEvent decodes `data` for `topic` and delivers it on the matching
+// `events` channel. Every send besides `chain_reorg` (handled off-goroutine
+// by `handleReorg`) is non-blocking and drops the event with a warning log
+// if the subscriber isn't keeping up, so a slow consumer of one topic can
+// never stall dispatch of the others on this shared SSE read loop.
+func (c *Client) dispatchEvent(ctx context.Context, topic string, data []byte, events *Events, dedupe *lru.Cache) {
+	logger := c.logger.Sugar()
+
+	switch topic {
+	case topicHead:
+		var raw headEvent
+		if err := json.Unmarshal(data, &raw); err != nil {
+			logger.Warnf("could not unmarshal `head` node event: %v", err)
+			return
+		}
+		slot, err := strconv.Atoi(raw.Slot)
+		if err != nil {
+			logger.Warnf("could not unmarshal slot from `head` node event: %v", err)
+			return
+		}
+		if seen(dedupe, topic, types.Slot(slot), raw.Block) {
+			return
+		}
+		if events.Head != nil {
+			select {
+			case events.Head <- HeadEvent{Coordinate: types.Coordinate{Slot: types.Slot(slot), Root: raw.Block}}:
+			default:
+				logger.Warnw("dropping head event, subscriber channel is full", "slot", slot)
+			}
+		}
+	case topicBlock:
+		var raw blockEvent
+		if err := json.Unmarshal(data, &raw); err != nil {
+			logger.Warnf("could not unmarshal `block` node event: %v", err)
+			return
+		}
+		slot, err := strconv.Atoi(raw.Slot)
+		if err != nil {
+			logger.Warnf("could not unmarshal slot from `block` node event: %v", err)
+			return
+		}
+		if seen(dedupe, topic, types.Slot(slot), raw.Block) {
+			return
+		}
+		if events.Block != nil {
+			select {
+			case events.Block <- BlockEvent{Slot: types.Slot(slot), Block: raw.Block}:
+			default:
+				logger.Warnw("dropping block event, subscriber channel is full", "slot", slot)
+			}
+		}
+	case topicFinalizedCheckpoint:
+		var raw finalizedCheckpointEvent
+		if err := json.Unmarshal(data, &raw); err != nil {
+			logger.Warnf("could not unmarshal `finalized_checkpoint` node event: %v", err)
+			return
+		}
+		epoch, err := strconv.ParseUint(raw.Epoch, 10, 64)
+		if err != nil {
+			logger.Warnf("could not unmarshal epoch from `finalized_checkpoint` node event: %v", err)
+			return
+		}
+		event := FinalizedCheckpointEvent{Epoch: types.Epoch(epoch), Block: raw.Block}
+		c.pruneBeforeFinalized(event)
+		if events.FinalizedCheckpoint != nil {
+			select {
+			case events.FinalizedCheckpoint <- event:
+			default:
+				logger.Warnw("dropping finalized_checkpoint event, subscriber channel is full", "epoch", epoch)
+			}
+		}
+	case topicChainReorg:
+		var raw reorgEvent
+		if err := json.Unmarshal(data, &raw); err != nil {
+			logger.Warnf("could not unmarshal `chain_reorg` node event: %v", err)
+			return
+		}
+		slot, err := strconv.Atoi(raw.Slot)
+		if err != nil {
+			logger.Warnf("could not unmarshal slot from `chain_reorg` node event: %v", err)
+			return
+		}
+		depth, err := strconv.ParseUint(raw.Depth, 10, 64)
+		if err != nil {
+			logger.Warnf("could not unmarshal depth from `chain_reorg` node event: %v", err)
+			return
+		}
+		if seen(dedupe, topic, types.Slot(slot), raw.NewHeadBlock) {
+			return
+		}
+		event := ReorgEvent{
+			Slot:         types.Slot(slot),
+			Depth:        depth,
+			OldHeadBlock: raw.OldHeadBlock,
+			NewHeadBlock: raw.NewHeadBlock,
+		}
+		go c.handleReorg(ctx, event, events)
+	case topicPayloadAttributes:
+		var raw payloadAttributesEvent
+		if err := json.Unmarshal(data, &raw); err != nil {
+			logger.Warnf("could not unmarshal `payload_attributes` node event: %v", err)
+			return
+		}
+		proposalSlot, err := strconv.Atoi(raw.Data.ProposalSlot)
+		if err != nil {
+			logger.Warnf("could not unmarshal proposal slot from `payload_attributes` node event: %v", err)
+			return
+		}
+		if events.PayloadAttributes != nil {
+			event := PayloadAttributesEvent{
+				ProposalSlot: types.Slot(proposalSlot),
+				FeeRecipient: raw.Data.PayloadAttributes.FeeRecipient,
+				Withdrawals:  raw.Data.PayloadAttributes.Withdrawals,
+			}
+			select {
+			case events.PayloadAttributes <- event:
+			default:
+				logger.Warnw("dropping payload_attributes event, subscriber channel is full", "slot", proposalSlot)
+			}
+		}
+	default:
+		logger.Debugf("ignoring unrecognized event topic %q", topic)
+	}
+}
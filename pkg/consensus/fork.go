@@ -0,0 +1,90 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/protolambda/eth2api"
+	"github.com/protolambda/ztyp/tree"
+	"github.com/protolambda/zrnt/eth2/beacon/bellatrix"
+	"github.com/protolambda/zrnt/eth2/beacon/capella"
+	"github.com/protolambda/zrnt/eth2/beacon/configs"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/beacon/electra"
+	"github.com/ralexstokes/relay-monitor/pkg/types"
+)
+
+// ErrPreBellatrixBlock is returned by `extractExecutionPayload` when a block
+// predates the Bellatrix fork and so carries no execution payload. Callers
+// should treat this as a signal to skip the slot rather than a hard failure.
+var ErrPreBellatrixBlock = errors.New("block predates the Bellatrix fork and carries no execution payload")
+
+// executionPayloadSummary captures everything the monitor needs out of a
+// beacon block's execution payload, independent of which fork produced it.
+type executionPayloadSummary struct {
+	fork      string
+	blockHash types.Hash
+
+	// blobKZGCommitmentsRoot is only populated from Deneb onward; it lets
+	// bid-validation logic compare a relay's promised blob bundle against
+	// what actually landed on chain.
+	blobKZGCommitmentsRoot *types.Root
+
+	// deposits holds any EIP-6110 deposit requests carried directly in the
+	// execution payload. Only populated from Electra onward.
+	deposits []depositRequest
+}
+
+// depositRequest mirrors an EIP-6110 deposit request from the execution
+// payload's `deposit_requests` list, in fork-independent form.
+type depositRequest struct {
+	publicKey             types.PublicKey
+	withdrawalCredentials types.Root
+	amount                uint64
+}
+
+// extractExecutionPayload dispatches on the beacon block's fork version and
+// pulls out the execution payload in a fork-independent shape. Pre-Bellatrix
+// blocks have no execution payload at all, so they return `ErrPreBellatrixBlock`.
+func extractExecutionPayload(signedBeaconBlock *eth2api.VersionedSignedBeaconBlock) (executionPayloadSummary, error) {
+	switch block := signedBeaconBlock.Data.(type) {
+	case *bellatrix.SignedBeaconBlock:
+		return executionPayloadSummary{
+			fork:      "bellatrix",
+			blockHash: types.Hash(block.Message.Body.ExecutionPayload.BlockHash),
+		}, nil
+	case *capella.SignedBeaconBlock:
+		return executionPayloadSummary{
+			fork:      "capella",
+			blockHash: types.Hash(block.Message.Body.ExecutionPayload.BlockHash),
+		}, nil
+	case *deneb.SignedBeaconBlock:
+		commitmentsRoot := types.Root(block.Message.Body.BlobKZGCommitments.HashTreeRoot(configs.Mainnet, tree.GetHashFn()))
+		return executionPayloadSummary{
+			fork:                   "deneb",
+			blockHash:              types.Hash(block.Message.Body.ExecutionPayload.BlockHash),
+			blobKZGCommitmentsRoot: &commitmentsRoot,
+		}, nil
+	case *electra.SignedBeaconBlock:
+		commitmentsRoot := types.Root(block.Message.Body.BlobKZGCommitments.HashTreeRoot(configs.Mainnet, tree.GetHashFn()))
+
+		requests := block.Message.Body.ExecutionRequests.Deposits
+		deposits := make([]depositRequest, 0, len(requests))
+		for _, request := range requests {
+			deposits = append(deposits, depositRequest{
+				publicKey:             types.PublicKey(request.Pubkey),
+				withdrawalCredentials: types.Root(request.WithdrawalCredentials),
+				amount:                uint64(request.Amount),
+			})
+		}
+
+		return executionPayloadSummary{
+			fork:                   "electra",
+			blockHash:              types.Hash(block.Message.Body.ExecutionPayload.BlockHash),
+			blobKZGCommitmentsRoot: &commitmentsRoot,
+			deposits:               deposits,
+		}, nil
+	default:
+		return executionPayloadSummary{}, fmt.Errorf("%w: %T", ErrPreBellatrixBlock, signedBeaconBlock.Data)
+	}
+}
@@ -0,0 +1,230 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/protolambda/eth2api"
+	"github.com/protolambda/eth2api/client/nodeapi"
+	"go.uber.org/zap"
+)
+
+const (
+	// minQuarantine is the initial backoff applied to an endpoint the first
+	// time it fails; it doubles on each subsequent failure up to
+	// maxQuarantine. It is kept comfortably above `reconnectBackoff` (see
+	// events.go) so a dropped SSE connection actually fails over to the next
+	// endpoint instead of reconnecting to the same one once its quarantine
+	// has already lapsed.
+	minQuarantine = 5 * time.Second
+	maxQuarantine = 2 * time.Minute
+
+	syncingProbeInterval = 30 * time.Second
+)
+
+// endpointHealth tracks liveness for a single beacon endpoint in the pool.
+type endpointHealth struct {
+	client *eth2api.Eth2HttpClient
+
+	mu               sync.Mutex
+	quarantinedUntil time.Time
+	backoff          time.Duration
+	syncing          bool
+}
+
+func (e *endpointHealth) addr() string {
+	return e.client.Addr
+}
+
+func (e *endpointHealth) isAvailable(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return now.After(e.quarantinedUntil)
+}
+
+func (e *endpointHealth) isAvailableForWrites(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return now.After(e.quarantinedUntil) && !e.syncing
+}
+
+func (e *endpointHealth) markHealthy() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.quarantinedUntil = time.Time{}
+	e.backoff = 0
+}
+
+func (e *endpointHealth) markUnhealthy(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.backoff == 0 {
+		e.backoff = minQuarantine
+	} else {
+		e.backoff *= 2
+		if e.backoff > maxQuarantine {
+			e.backoff = maxQuarantine
+		}
+	}
+	e.quarantinedUntil = now.Add(e.backoff)
+}
+
+func (e *endpointHealth) setSyncing(syncing bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.syncing = syncing
+}
+
+// endpointPool is a small priority-ordered, health-checked pool of beacon
+// node endpoints. Calls always prefer the first available endpoint (the
+// "primary") and fall back to secondaries in order, so a single
+// configuration of `[primary, ...secondaries]` degrades gracefully rather
+// than failing outright when the primary lags, restarts, or 5xxs.
+type endpointPool struct {
+	logger    *zap.Logger
+	endpoints []*endpointHealth
+}
+
+func newEndpointPool(logger *zap.Logger, addrs []string) (*endpointPool, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("at least one consensus endpoint is required")
+	}
+
+	endpoints := make([]*endpointHealth, 0, len(addrs))
+	for _, addr := range addrs {
+		httpClient := &eth2api.Eth2HttpClient{
+			Addr: addr,
+			Cli: &http.Client{
+				Transport: &http.Transport{
+					MaxIdleConnsPerHost: 128,
+				},
+				Timeout: clientTimeoutSec * time.Second,
+			},
+			Codec: eth2api.JSONCodec{},
+		}
+		endpoints = append(endpoints, &endpointHealth{client: httpClient})
+	}
+
+	return &endpointPool{logger: logger, endpoints: endpoints}, nil
+}
+
+// readableClients returns every endpoint that isn't quarantined, primary
+// first, including endpoints that are currently syncing -- stale data is
+// fine for historic slot lookups.
+func (p *endpointPool) readableClients() []*eth2api.Eth2HttpClient {
+	now := time.Now()
+	clients := make([]*eth2api.Eth2HttpClient, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		if endpoint.isAvailable(now) {
+			clients = append(clients, endpoint.client)
+		}
+	}
+	return clients
+}
+
+// writableClients is the same as `readableClients` but additionally
+// excludes endpoints that are currently syncing, since they may not yet
+// have applied writes (e.g. validator registrations) that reads depend on.
+func (p *endpointPool) writableClients() []*eth2api.Eth2HttpClient {
+	now := time.Now()
+	clients := make([]*eth2api.Eth2HttpClient, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		if endpoint.isAvailableForWrites(now) {
+			clients = append(clients, endpoint.client)
+		}
+	}
+	return clients
+}
+
+func (p *endpointPool) byAddr(addr string) *endpointHealth {
+	for _, endpoint := range p.endpoints {
+		if endpoint.addr() == addr {
+			return endpoint
+		}
+	}
+	return nil
+}
+
+func (p *endpointPool) markHealthy(addr string) {
+	if endpoint := p.byAddr(addr); endpoint != nil {
+		endpoint.markHealthy()
+	}
+}
+
+func (p *endpointPool) markUnhealthy(addr string) {
+	if endpoint := p.byAddr(addr); endpoint != nil {
+		endpoint.markUnhealthy(time.Now())
+	}
+}
+
+// primaryAddr returns the first currently-available endpoint's address, for
+// callers (like the SSE subscriptions) that need a single target rather than
+// per-call failover.
+func (p *endpointPool) primaryAddr() string {
+	clients := p.readableClients()
+	if len(clients) == 0 {
+		return p.endpoints[0].client.Addr
+	}
+	return clients[0].Addr
+}
+
+// probeSyncing periodically polls `/eth/v1/node/syncing` on every endpoint
+// and marks syncing nodes unhealthy for writes, until `ctx` is canceled.
+func (p *endpointPool) probeSyncing(ctx context.Context) {
+	ticker := time.NewTicker(syncingProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, endpoint := range p.endpoints {
+				var response eth2api.SyncingResponse
+				err := nodeapi.Syncing(ctx, endpoint.client, &response)
+				if err != nil {
+					p.logger.Sugar().Warnf("could not probe syncing status for %s: %v", endpoint.addr(), err)
+					continue
+				}
+				endpoint.setSyncing(response.IsSyncing)
+			}
+		}
+	}
+}
+
+// withFailover calls `fn` against each non-syncing, healthy endpoint in
+// priority order, stopping at the first success and marking endpoints that
+// error or time out as unhealthy so subsequent calls quarantine them with
+// exponential backoff. Use this for calls that need up-to-date head state,
+// e.g. proposer duties or validator status.
+func (p *endpointPool) withFailover(fn func(client *eth2api.Eth2HttpClient) error) error {
+	return p.withFailoverClients(p.writableClients(), fn)
+}
+
+// withReadFailover is the same as withFailover, except it also considers
+// endpoints that are currently syncing. Use this for historic slot lookups,
+// which tolerate a node that hasn't yet caught up to head.
+func (p *endpointPool) withReadFailover(fn func(client *eth2api.Eth2HttpClient) error) error {
+	return p.withFailoverClients(p.readableClients(), fn)
+}
+
+func (p *endpointPool) withFailoverClients(clients []*eth2api.Eth2HttpClient, fn func(client *eth2api.Eth2HttpClient) error) error {
+	if len(clients) == 0 {
+		return fmt.Errorf("no healthy consensus endpoints available")
+	}
+
+	var lastErr error
+	for _, client := range clients {
+		if err := fn(client); err != nil {
+			p.markUnhealthy(client.Addr)
+			lastErr = err
+			continue
+		}
+		p.markHealthy(client.Addr)
+		return nil
+	}
+	return fmt.Errorf("all consensus endpoints failed, last error: %w", lastErr)
+}